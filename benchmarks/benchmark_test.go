@@ -218,6 +218,35 @@ func BenchmarkConcurrentAccess(b *testing.B) {
 	})
 }
 
+// BenchmarkPriceForQuantity compares VWAP depth-walking cost against the
+// plain top-of-book lookups at varying book depths.
+func BenchmarkPriceForQuantity_100(b *testing.B) {
+	ob := orderbook.New()
+	seedBook(ob, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = ob.PriceForQuantity(orderbook.SideBuy, 25.0)
+	}
+}
+
+func BenchmarkPriceForQuantity_1k(b *testing.B) {
+	ob := orderbook.New()
+	seedBook(ob, 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = ob.PriceForQuantity(orderbook.SideBuy, 250.0)
+	}
+}
+
+func BenchmarkPriceForQuantity_10k(b *testing.B) {
+	ob := orderbook.New()
+	seedBook(ob, 10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = ob.PriceForQuantity(orderbook.SideBuy, 2500.0)
+	}
+}
+
 // createTestSnapshot creates a test snapshot with the specified number of levels
 func createTestSnapshot(levels int) orderbook.SnapshotMsg {
 	bids := make([][]string, levels)
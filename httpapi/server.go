@@ -0,0 +1,204 @@
+// Package httpapi exposes one or more orderbook.OrderBook instances over
+// HTTP/JSON, modeled after Horizon's /order_book ingestion endpoint. It
+// lets the process act as a sidecar for other services rather than only
+// printing to logs.
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"raven-dev-challenge/orderbook"
+)
+
+const defaultLimit = 20
+
+// Server serves HTTP requests against a fixed set of order books, keyed
+// by lowercase symbol (e.g. "btcusdt").
+type Server struct {
+	books map[string]*orderbook.OrderBook
+}
+
+// NewServer returns a Server for the given symbol -> OrderBook mapping.
+// Symbols are matched case-insensitively.
+func NewServer(books map[string]*orderbook.OrderBook) *Server {
+	normalized := make(map[string]*orderbook.OrderBook, len(books))
+	for symbol, ob := range books {
+		normalized[strings.ToLower(symbol)] = ob
+	}
+	return &Server{books: normalized}
+}
+
+// Handler returns the routed http.Handler for this server.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orderbook", s.handleOrderBook)
+	mux.HandleFunc("/orderbook/best", s.handleBest)
+	mux.HandleFunc("/orderbook/vwap", s.handleVWAP)
+	mux.HandleFunc("/orderbook/stream", s.handleStream)
+	return mux
+}
+
+// lookupBook resolves the "symbol" query parameter to a registered
+// OrderBook, writing a 404 and returning ok=false if it isn't known.
+func (s *Server) lookupBook(w http.ResponseWriter, r *http.Request) (ob *orderbook.OrderBook, symbol string, ok bool) {
+	symbol = strings.ToLower(r.URL.Query().Get("symbol"))
+	ob, found := s.books[symbol]
+	if !found {
+		http.Error(w, fmt.Sprintf("unknown symbol %q", symbol), http.StatusNotFound)
+		return nil, "", false
+	}
+	return ob, symbol, true
+}
+
+type bookResponse struct {
+	Symbol       string            `json:"symbol"`
+	Bids         []orderbook.Level `json:"bids"`
+	Asks         []orderbook.Level `json:"asks"`
+	LastUpdateID int64             `json:"lastUpdateId"`
+}
+
+// GET /orderbook?symbol=btcusdt&limit=20
+func (s *Server) handleOrderBook(w http.ResponseWriter, r *http.Request) {
+	ob, symbol, ok := s.lookupBook(w, r)
+	if !ok {
+		return
+	}
+
+	limit := defaultLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			limit = n
+		}
+	}
+
+	writeJSON(w, bookResponse{
+		Symbol:       symbol,
+		Bids:         ob.GetBids(limit),
+		Asks:         ob.GetAsks(limit),
+		LastUpdateID: ob.GetLastID(),
+	})
+}
+
+type bestResponse struct {
+	Symbol string  `json:"symbol"`
+	Bid    float64 `json:"bid"`
+	BidQty float64 `json:"bidQty"`
+	Ask    float64 `json:"ask"`
+	AskQty float64 `json:"askQty"`
+}
+
+// GET /orderbook/best?symbol=btcusdt
+func (s *Server) handleBest(w http.ResponseWriter, r *http.Request) {
+	ob, symbol, ok := s.lookupBook(w, r)
+	if !ok {
+		return
+	}
+
+	bid, bidQty, _ := ob.BestBid()
+	ask, askQty, _ := ob.BestAsk()
+	writeJSON(w, bestResponse{Symbol: symbol, Bid: bid, BidQty: bidQty, Ask: ask, AskQty: askQty})
+}
+
+type vwapResponse struct {
+	Symbol      string  `json:"symbol"`
+	Side        string  `json:"side"`
+	Quantity    float64 `json:"quantity"`
+	AvgPrice    float64 `json:"avgPrice"`
+	Filled      float64 `json:"filled"`
+	FullyFilled bool    `json:"fullyFilled"`
+}
+
+// GET /orderbook/vwap?symbol=btcusdt&side=buy&qty=1.5
+func (s *Server) handleVWAP(w http.ResponseWriter, r *http.Request) {
+	ob, symbol, ok := s.lookupBook(w, r)
+	if !ok {
+		return
+	}
+
+	var side orderbook.Side
+	switch r.URL.Query().Get("side") {
+	case "buy":
+		side = orderbook.SideBuy
+	case "sell":
+		side = orderbook.SideSell
+	default:
+		http.Error(w, `side must be "buy" or "sell"`, http.StatusBadRequest)
+		return
+	}
+
+	qty, err := strconv.ParseFloat(r.URL.Query().Get("qty"), 64)
+	if err != nil {
+		http.Error(w, "qty must be a number", http.StatusBadRequest)
+		return
+	}
+
+	avgPrice, filled, fullyFilled := ob.PriceForQuantity(side, qty)
+	writeJSON(w, vwapResponse{
+		Symbol:      symbol,
+		Side:        r.URL.Query().Get("side"),
+		Quantity:    qty,
+		AvgPrice:    avgPrice,
+		Filled:      filled,
+		FullyFilled: fullyFilled,
+	})
+}
+
+// GET /orderbook/stream?symbol=btcusdt
+//
+// Pushes a bestResponse as a server-sent event every time the book's top
+// of book changes, throttled to avoid flooding slow clients during
+// microbursts. The subscription is torn down via the returned unsubscribe
+// func as soon as the request context ends, so a closed connection does
+// not leak a throttled handler.
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	ob, symbol, ok := s.lookupBook(w, r)
+	if !ok {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	events := make(chan bestResponse, 16)
+	unsubscribe := ob.OnBestPriceChangedThrottled(200*time.Millisecond, func(bid, ask float64) {
+		select {
+		case events <- bestResponse{Symbol: symbol, Bid: bid, Ask: ask}:
+		default:
+			// Slow client: drop this tick, the next one will carry fresher prices.
+		}
+	})
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev := <-events:
+			payload, err := json.Marshal(ev)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			flusher.Flush()
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
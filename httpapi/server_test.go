@@ -0,0 +1,86 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"raven-dev-challenge/orderbook"
+)
+
+func testBook(t *testing.T) *orderbook.OrderBook {
+	ob := orderbook.New()
+	err := ob.ApplySnapshot(orderbook.SnapshotMsg{
+		LastUpdateID: 42,
+		Bids:         [][]string{{"100.00", "2.0"}, {"99.50", "1.0"}},
+		Asks:         [][]string{{"101.00", "3.0"}, {"101.50", "1.0"}},
+	})
+	if err != nil {
+		t.Fatalf("ApplySnapshot: %v", err)
+	}
+	return ob
+}
+
+func TestHandleOrderBook(t *testing.T) {
+	srv := NewServer(map[string]*orderbook.OrderBook{"BTCUSDT": testBook(t)})
+
+	req := httptest.NewRequest(http.MethodGet, "/orderbook?symbol=btcusdt&limit=1", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var resp bookResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.LastUpdateID != 42 || len(resp.Bids) != 1 || resp.Bids[0].Price != 100.00 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestHandleBest(t *testing.T) {
+	srv := NewServer(map[string]*orderbook.OrderBook{"btcusdt": testBook(t)})
+
+	req := httptest.NewRequest(http.MethodGet, "/orderbook/best?symbol=btcusdt", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	var resp bestResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Bid != 100.00 || resp.Ask != 101.00 {
+		t.Fatalf("unexpected best prices: %+v", resp)
+	}
+}
+
+func TestHandleVWAP(t *testing.T) {
+	srv := NewServer(map[string]*orderbook.OrderBook{"btcusdt": testBook(t)})
+
+	req := httptest.NewRequest(http.MethodGet, "/orderbook/vwap?symbol=btcusdt&side=buy&qty=4", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	var resp vwapResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !resp.FullyFilled || resp.Filled != 4 {
+		t.Fatalf("expected a full 4-unit fill, got %+v", resp)
+	}
+}
+
+func TestHandleOrderBookUnknownSymbol(t *testing.T) {
+	srv := NewServer(map[string]*orderbook.OrderBook{"btcusdt": testBook(t)})
+
+	req := httptest.NewRequest(http.MethodGet, "/orderbook?symbol=ethusdt", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown symbol, got %d", rec.Code)
+	}
+}
@@ -2,41 +2,86 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
+	"net/http"
 	"time"
 
+	"raven-dev-challenge/httpapi"
 	"raven-dev-challenge/orderbook"
+	"raven-dev-challenge/orderbook/graph"
 	"raven-dev-challenge/wsclient"
+	"raven-dev-challenge/wsclient/binance"
 )
 
+var httpAddr = flag.String("http", "", "if set, serve the order book over HTTP on this address (e.g. :8080)")
+
+// pair describes one symbol to subscribe to and how it decomposes into
+// base/quote assets for the arbitrage graph.
+type pair struct {
+	symbol string
+	base   string
+	quote  string
+}
+
+var pairs = []pair{
+	{symbol: "btcusdt", base: "BTC", quote: "USDT"},
+	{symbol: "ethusdt", base: "ETH", quote: "USDT"},
+	{symbol: "ethbtc", base: "ETH", quote: "BTC"},
+}
+
 func main() {
+	flag.Parse()
 	log.Println("Starting order book demo...")
 
-	ob := orderbook.New()
-	client := wsclient.New("btcusdt", ob)
+	exch := binance.New()
+	configs := make([]wsclient.PairConfig, len(pairs))
+	for i, p := range pairs {
+		configs[i] = wsclient.PairConfig{Exchange: exch, Symbol: p.symbol}
+	}
+	manager := wsclient.NewManager(configs, 0)
+
+	g := graph.NewGraph()
+	books := make(map[string]*orderbook.OrderBook, len(pairs))
+	for _, p := range pairs {
+		ob, _ := manager.Book(exch.Name(), p.symbol)
+		books[p.symbol] = ob
+		g.AddBook(p.base, p.quote, ob)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Run WebSocket client in background
-	go func() {
-		if err := client.Run(ctx); err != nil {
-			log.Printf("WebSocket error: %v", err)
-		}
-	}()
+	go manager.Run(ctx)
+
+	if *httpAddr != "" {
+		server := httpapi.NewServer(books)
+		go func() {
+			log.Printf("Serving HTTP on %s", *httpAddr)
+			if err := http.ListenAndServe(*httpAddr, server.Handler()); err != nil {
+				log.Printf("HTTP server error: %v", err)
+			}
+		}()
+	}
 
-	// Print best bid/ask every second
+	// Print best bid/ask for the primary pair and scan for arbitrage
+	// cycles every second.
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 
+	primary := books["btcusdt"]
 	for range ticker.C {
-		bid, bqty, hasBid := ob.BestBid()
-		ask, aqty, hasAsk := ob.BestAsk()
+		bid, bqty, hasBid := primary.BestBid()
+		ask, aqty, hasAsk := primary.BestAsk()
 
 		if hasBid && hasAsk {
 			spread := ask - bid
 			log.Printf("BID: $%.2f (%.4f) | ASK: $%.2f (%.4f) | Spread: $%.2f",
 				bid, bqty, ask, aqty, spread)
 		}
+
+		for _, cycle := range g.DetectArbitrage(3) {
+			log.Printf("Arbitrage opportunity: %d hops, profit factor %.4f", len(cycle.Hops), cycle.Profit)
+		}
 	}
 }
@@ -0,0 +1,69 @@
+package orderbook
+
+// PriceForQuantity walks the book on the given side and computes the
+// volume-weighted average price needed to fill qty. filled reports how
+// much quantity was actually available; ok is false if the book could
+// not fully satisfy qty (a partial fill), in which case avgPrice/filled
+// describe what was available.
+func (ob *OrderBook) PriceForQuantity(side Side, qty float64) (avgPrice, filled float64, ok bool) {
+	lm := ob.levelMapFor(side)
+
+	var remaining = qty
+	var cost float64
+	for _, price := range lm.prices {
+		if remaining <= 0 {
+			break
+		}
+		available := lm.qtyAt[price]
+		take := available
+		if take > remaining {
+			take = remaining
+		}
+		cost += price * take
+		filled += take
+		remaining -= take
+	}
+
+	if filled == 0 {
+		return 0, 0, false
+	}
+	return cost / filled, filled, remaining <= 0
+}
+
+// QuantityForPrice returns the cumulative quantity available on the given
+// side at or better than price. For bids this sums levels >= price; for
+// asks it sums levels <= price.
+func (ob *OrderBook) QuantityForPrice(side Side, price float64) float64 {
+	lm := ob.levelMapFor(side)
+
+	var total float64
+	for _, p := range lm.prices {
+		if lm.isBid && p < price {
+			break
+		}
+		if !lm.isBid && p > price {
+			break
+		}
+		total += lm.qtyAt[p]
+	}
+	return total
+}
+
+// DepthPrice returns the price at the given depth level (0 = best), or
+// false if the book does not have that many levels.
+func (ob *OrderBook) DepthPrice(side Side, level int) (price float64, ok bool) {
+	lm := ob.levelMapFor(side)
+	if level < 0 || level >= len(lm.prices) {
+		return 0, false
+	}
+	return lm.prices[level], true
+}
+
+// levelMapFor returns the current levelMap for side.
+func (ob *OrderBook) levelMapFor(side Side) *levelMap {
+	s := ob.state.Load()
+	if side == SideBuy {
+		return s.asks
+	}
+	return s.bids
+}
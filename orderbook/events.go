@@ -0,0 +1,179 @@
+package orderbook
+
+import (
+	"sync"
+	"time"
+)
+
+// ChangeType classifies how a single price level changed between two
+// ApplyUpdate/ApplySnapshot calls.
+type ChangeType int
+
+const (
+	LevelAdded ChangeType = iota
+	LevelModified
+	LevelRemoved
+)
+
+// LevelChange describes one price level's change within an update.
+type LevelChange struct {
+	Price    float64
+	Quantity float64
+	Type     ChangeType
+}
+
+// SnapshotView is an immutable view of the book right after a snapshot
+// was applied, handed to OnSnapshot subscribers.
+type SnapshotView struct {
+	Bids         []Level
+	Asks         []Level
+	LastUpdateID int64
+}
+
+// UpdateView describes what changed in a single ApplyUpdate call, handed
+// to OnUpdate subscribers.
+type UpdateView struct {
+	BidChanges   []LevelChange
+	AskChanges   []LevelChange
+	LastUpdateID int64
+	TopChanged   bool
+}
+
+type snapshotHandler func(SnapshotView)
+type updateHandler func(UpdateView)
+type bestPriceHandler func(bid, ask float64)
+
+// throttledHandler coalesces bursts of best-price updates so a subscriber
+// fires at most once per minInterval, always with the latest values.
+type throttledHandler struct {
+	minInterval time.Duration
+	fn          bestPriceHandler
+
+	mu         sync.Mutex
+	lastFired  time.Time
+	pending    bool
+	pendingBid float64
+	pendingAsk float64
+}
+
+func (th *throttledHandler) notify(bid, ask float64) {
+	th.mu.Lock()
+	now := time.Now()
+	elapsed := now.Sub(th.lastFired)
+	if elapsed >= th.minInterval {
+		th.lastFired = now
+		th.mu.Unlock()
+		th.fn(bid, ask)
+		return
+	}
+	th.pendingBid = bid
+	th.pendingAsk = ask
+	if th.pending {
+		th.mu.Unlock()
+		return
+	}
+	th.pending = true
+	wait := th.minInterval - elapsed
+	th.mu.Unlock()
+
+	time.AfterFunc(wait, func() {
+		th.mu.Lock()
+		th.pending = false
+		th.lastFired = time.Now()
+		bid, ask := th.pendingBid, th.pendingAsk
+		th.mu.Unlock()
+		th.fn(bid, ask)
+	})
+}
+
+// handlers holds every callback registered on an OrderBook. Registration
+// and dispatch both take handlers.mu, but dispatch copies the slice it
+// needs and calls handlers after releasing it, so handlers never run
+// with ob.writeMu held.
+type handlers struct {
+	mu                sync.Mutex
+	onSnapshot        []snapshotHandler
+	onUpdate          []updateHandler
+	onBestPrice       []bestPriceHandler
+	onBestPriceThrott []*throttledHandler
+}
+
+// OnSnapshot registers fn to be called after every ApplySnapshot.
+func (ob *OrderBook) OnSnapshot(fn func(SnapshotView)) {
+	ob.handlers.mu.Lock()
+	defer ob.handlers.mu.Unlock()
+	ob.handlers.onSnapshot = append(ob.handlers.onSnapshot, fn)
+}
+
+// OnUpdate registers fn to be called after every ApplyUpdate that
+// changes at least one level.
+func (ob *OrderBook) OnUpdate(fn func(UpdateView)) {
+	ob.handlers.mu.Lock()
+	defer ob.handlers.mu.Unlock()
+	ob.handlers.onUpdate = append(ob.handlers.onUpdate, fn)
+}
+
+// OnBestPriceChanged registers fn to be called whenever BestBid or
+// BestAsk moves and both sides of the book are non-empty afterwards.
+func (ob *OrderBook) OnBestPriceChanged(fn func(bid, ask float64)) {
+	ob.handlers.mu.Lock()
+	defer ob.handlers.mu.Unlock()
+	ob.handlers.onBestPrice = append(ob.handlers.onBestPrice, fn)
+}
+
+// OnBestPriceChangedThrottled is like OnBestPriceChanged but coalesces
+// bursts of changes, firing at most once per minInterval. The returned
+// unsubscribe func removes the handler; callers that register one per
+// request (e.g. an HTTP stream) must call it when the request ends, or
+// the handler leaks for the life of the process.
+func (ob *OrderBook) OnBestPriceChangedThrottled(minInterval time.Duration, fn func(bid, ask float64)) (unsubscribe func()) {
+	th := &throttledHandler{minInterval: minInterval, fn: fn}
+	ob.handlers.mu.Lock()
+	ob.handlers.onBestPriceThrott = append(ob.handlers.onBestPriceThrott, th)
+	ob.handlers.mu.Unlock()
+
+	return func() {
+		ob.handlers.mu.Lock()
+		defer ob.handlers.mu.Unlock()
+		for i, h := range ob.handlers.onBestPriceThrott {
+			if h == th {
+				ob.handlers.onBestPriceThrott = append(ob.handlers.onBestPriceThrott[:i], ob.handlers.onBestPriceThrott[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (ob *OrderBook) dispatchSnapshot(view SnapshotView) {
+	ob.handlers.mu.Lock()
+	hs := append([]snapshotHandler(nil), ob.handlers.onSnapshot...)
+	ob.handlers.mu.Unlock()
+
+	for _, h := range hs {
+		h(view)
+	}
+}
+
+func (ob *OrderBook) dispatchUpdate(view UpdateView) {
+	ob.handlers.mu.Lock()
+	hs := append([]updateHandler(nil), ob.handlers.onUpdate...)
+	ob.handlers.mu.Unlock()
+
+	for _, h := range hs {
+		h(view)
+	}
+}
+
+func (ob *OrderBook) dispatchBestPriceChanged(bid, ask float64) {
+	ob.handlers.mu.Lock()
+	hs := append([]bestPriceHandler(nil), ob.handlers.onBestPrice...)
+	ths := append([]*throttledHandler(nil), ob.handlers.onBestPriceThrott...)
+	ob.handlers.mu.Unlock()
+
+	for _, h := range hs {
+		h(bid, ask)
+	}
+	for _, th := range ths {
+		th.notify(bid, ask)
+	}
+}
@@ -0,0 +1,203 @@
+// Package graph maintains a directed graph of trading pairs so that
+// multiple OrderBooks can be queried together, e.g. to find the best
+// route to convert one asset into another, or to spot triangular
+// arbitrage cycles. It mirrors the shape of Stellar's OrderBookGraph
+// pathfinding, but walks depth via orderbook.OrderBook's VWAP API
+// instead of a static order list.
+package graph
+
+import (
+	"strings"
+	"sync"
+
+	"raven-dev-challenge/orderbook"
+)
+
+// maxWalkLevels bounds how deep we walk a book when simulating a
+// quote-denominated spend (buying base with a fixed amount of quote).
+// Books rarely carry more live levels than this; it exists purely as a
+// safety cap against pathological books.
+const maxWalkLevels = 10000
+
+// Hop describes one leg of a conversion: amountIn units of From were
+// turned into amountOut units of To by walking Book's depth.
+type Hop struct {
+	From, To  string
+	Book      *orderbook.OrderBook
+	AmountIn  float64
+	AmountOut float64
+}
+
+// Cycle is a closed path (same asset at both ends) with its net
+// multiplier: Profit > 1.0 means the cycle is profitable before fees.
+type Cycle struct {
+	Hops   []Hop
+	Profit float64
+}
+
+// edge is one directed conversion: sell base for quote (isSell=true), or
+// buy base with quote (isSell=false).
+type edge struct {
+	from, to string
+	book     *orderbook.OrderBook
+	isSell   bool
+}
+
+// Graph indexes OrderBooks by trading pair and exposes pathfinding over
+// the implied currency conversion graph.
+type Graph struct {
+	mu    sync.RWMutex
+	edges map[string][]edge // keyed by asset symbol (the "from" side)
+}
+
+// NewGraph returns an empty Graph.
+func NewGraph() *Graph {
+	return &Graph{edges: make(map[string][]edge)}
+}
+
+// AddBook registers an OrderBook for the base/quote pair (e.g. "BTC",
+// "USDT" for BTCUSDT), creating edges in both directions: selling base
+// for quote, and buying base with quote.
+func (g *Graph) AddBook(base, quote string, ob *orderbook.OrderBook) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.edges[base] = append(g.edges[base], edge{from: base, to: quote, book: ob, isSell: true})
+	g.edges[quote] = append(g.edges[quote], edge{from: quote, to: base, book: ob, isSell: false})
+}
+
+// convert walks e.book's depth to turn amountIn units of e.from into
+// units of e.to, returning the amount received and whether the book had
+// enough depth to fill it completely.
+func convert(e edge, amountIn float64) (amountOut float64, ok bool) {
+	if e.isSell {
+		// Selling `from` (base) for `to` (quote): hit the bids.
+		avgPrice, filled, filledOK := e.book.PriceForQuantity(orderbook.SideSell, amountIn)
+		return avgPrice * filled, filledOK
+	}
+
+	// Buying `to` (base) by spending `from` (quote): hit the asks,
+	// accumulating base received until the quote spend is exhausted.
+	levels := e.book.GetAsks(maxWalkLevels)
+	remaining := amountIn
+	for _, lvl := range levels {
+		if remaining <= 0 {
+			break
+		}
+		levelCost := lvl.Price * lvl.Quantity
+		if levelCost <= remaining {
+			amountOut += lvl.Quantity
+			remaining -= levelCost
+		} else {
+			amountOut += remaining / lvl.Price
+			remaining = 0
+		}
+	}
+	return amountOut, remaining <= 0
+}
+
+// FindBestPath searches up to maxHops conversions from sell to buy and
+// returns the path yielding the highest amountOut. It returns a nil path
+// and zero amountOut if no route exists within maxHops.
+func (g *Graph) FindBestPath(sell, buy string, amountIn float64, maxHops int) (path []Hop, amountOut float64) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	visited := map[string]bool{sell: true}
+	var bestPath []Hop
+	var bestOut float64
+
+	var dfs func(asset string, amount float64, hops []Hop)
+	dfs = func(asset string, amount float64, hops []Hop) {
+		if asset == buy && len(hops) > 0 {
+			if amount > bestOut {
+				bestOut = amount
+				bestPath = append([]Hop(nil), hops...)
+			}
+		}
+		if len(hops) >= maxHops {
+			return
+		}
+		for _, e := range g.edges[asset] {
+			if visited[e.to] {
+				continue
+			}
+			out, ok := convert(e, amount)
+			if !ok || out <= 0 {
+				continue
+			}
+			visited[e.to] = true
+			dfs(e.to, out, append(hops, Hop{From: e.from, To: e.to, Book: e.book, AmountIn: amount, AmountOut: out}))
+			delete(visited, e.to)
+		}
+	}
+	dfs(sell, amountIn, nil)
+
+	return bestPath, bestOut
+}
+
+// canonicalCycleKey identifies a cycle independent of which asset it was
+// discovered starting from: it rotates the hops so the lexicographically
+// smallest "from" asset comes first, then joins the rotated "from"
+// sequence. The same real cycle walked from any of its assets yields the
+// same key.
+func canonicalCycleKey(hops []Hop) string {
+	start := 0
+	for i := 1; i < len(hops); i++ {
+		if hops[i].From < hops[start].From {
+			start = i
+		}
+	}
+	parts := make([]string, len(hops))
+	for i := range hops {
+		parts[i] = hops[(start+i)%len(hops)].From
+	}
+	return strings.Join(parts, "->")
+}
+
+// DetectArbitrage enumerates cycles of length 2..maxHops that return to
+// their starting asset with a net profit (amountOut > amountIn), seeding
+// each search with one unit of the starting asset. Since the search
+// starts from every asset in the graph, the same real cycle is found
+// once per asset it touches; each distinct cycle is reported only once.
+func (g *Graph) DetectArbitrage(maxHops int) []Cycle {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var cycles []Cycle
+	seen := make(map[string]bool)
+	for start := range g.edges {
+		visited := map[string]bool{start: true}
+
+		var dfs func(asset string, amount float64, hops []Hop)
+		dfs = func(asset string, amount float64, hops []Hop) {
+			if asset == start && len(hops) >= 2 {
+				if amount > 1.0 {
+					key := canonicalCycleKey(hops)
+					if !seen[key] {
+						seen[key] = true
+						cycles = append(cycles, Cycle{Hops: append([]Hop(nil), hops...), Profit: amount})
+					}
+				}
+				return
+			}
+			if len(hops) >= maxHops {
+				return
+			}
+			for _, e := range g.edges[asset] {
+				if e.to != start && visited[e.to] {
+					continue
+				}
+				out, ok := convert(e, amount)
+				if !ok || out <= 0 {
+					continue
+				}
+				visited[e.to] = true
+				dfs(e.to, out, append(hops, Hop{From: e.from, To: e.to, Book: e.book, AmountIn: amount, AmountOut: out}))
+				delete(visited, e.to)
+			}
+		}
+		dfs(start, 1.0, nil)
+	}
+	return cycles
+}
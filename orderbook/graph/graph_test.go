@@ -0,0 +1,69 @@
+package graph
+
+import (
+	"strconv"
+	"testing"
+
+	"raven-dev-challenge/orderbook"
+)
+
+func bookWith(bidPrice, bidQty, askPrice, askQty float64) *orderbook.OrderBook {
+	ob := orderbook.New()
+	snap := orderbook.SnapshotMsg{
+		LastUpdateID: 1,
+		Bids:         [][]string{{fmtFloat(bidPrice), fmtFloat(bidQty)}},
+		Asks:         [][]string{{fmtFloat(askPrice), fmtFloat(askQty)}},
+	}
+	_ = ob.ApplySnapshot(snap)
+	return ob
+}
+
+func fmtFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func TestFindBestPath(t *testing.T) {
+	g := NewGraph()
+	// BTC/USDT: 1 BTC sells for 10 USDT (bid side).
+	g.AddBook("BTC", "USDT", bookWith(10, 5, 11, 5))
+
+	path, out := g.FindBestPath("BTC", "USDT", 2, 1)
+	if len(path) != 1 {
+		t.Fatalf("expected a single-hop path, got %d hops", len(path))
+	}
+	if out != 20 {
+		t.Fatalf("expected 20 USDT out, got %.4f", out)
+	}
+}
+
+func TestDetectArbitrage(t *testing.T) {
+	g := NewGraph()
+	// BTC/USDT priced so selling 1 BTC then buying back costs less
+	// than 1 BTC: a profitable round trip.
+	g.AddBook("BTC", "USDT", bookWith(100, 10, 50, 10))
+
+	cycles := g.DetectArbitrage(4)
+	if len(cycles) == 0 {
+		t.Fatalf("expected at least one profitable cycle")
+	}
+	for _, c := range cycles {
+		if c.Profit <= 1.0 {
+			t.Fatalf("cycle reported as arbitrage but profit %.4f <= 1.0", c.Profit)
+		}
+	}
+}
+
+func TestDetectArbitrageDedupesRotatedCycles(t *testing.T) {
+	g := NewGraph()
+	// A 3-asset loop priced so BTC -> USDT -> ETH -> BTC is profitable.
+	// DetectArbitrage starts its search from every asset, so the same
+	// real cycle is discoverable from BTC, USDT, and ETH alike.
+	g.AddBook("BTC", "USDT", bookWith(100, 100, 100, 100))
+	g.AddBook("USDT", "ETH", bookWith(0.1, 10000, 0.1, 10000))
+	g.AddBook("ETH", "BTC", bookWith(12, 100, 12, 100))
+
+	cycles := g.DetectArbitrage(3)
+	if len(cycles) != 1 {
+		t.Fatalf("expected exactly one deduped cycle, got %d", len(cycles))
+	}
+}
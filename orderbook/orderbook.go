@@ -4,6 +4,7 @@ import (
 	"errors"
 	"sort"
 	"sync"
+	"sync/atomic"
 )
 
 // levelMap stores price levels as both a map (for O(1) lookups) and a sorted slice
@@ -95,39 +96,68 @@ func (lm *levelMap) best() (price, qty float64, ok bool) {
 	return p, lm.qtyAt[p], true
 }
 
-type OrderBook struct {
-	mu     sync.RWMutex
+// clone returns a deep copy of lm so it can be mutated independently of
+// any bookState a reader might still be holding.
+func (lm *levelMap) clone() *levelMap {
+	prices := make([]float64, len(lm.prices))
+	copy(prices, lm.prices)
+	qtyAt := make(map[float64]float64, len(lm.qtyAt))
+	for price, qty := range lm.qtyAt {
+		qtyAt[price] = qty
+	}
+	return &levelMap{isBid: lm.isBid, prices: prices, qtyAt: qtyAt}
+}
+
+// bookState is an immutable snapshot of the book at a point in time.
+// Readers load a *bookState once and iterate it lock-free; writers build
+// a new bookState and publish it atomically rather than mutating one in
+// place.
+type bookState struct {
 	lastID int64
 	bids   *levelMap
 	asks   *levelMap
 }
 
+type OrderBook struct {
+	// writeMu serializes ApplySnapshot/ApplyUpdate calls so two writers
+	// can't race to publish state built from the same stale read. It is
+	// never held by readers.
+	writeMu  sync.Mutex
+	state    atomic.Pointer[bookState]
+	handlers handlers
+}
+
 func New() *OrderBook {
-	return &OrderBook{
+	ob := &OrderBook{}
+	ob.state.Store(&bookState{
 		bids: newLevelMap(true),
 		asks: newLevelMap(false),
-	}
+	})
+	return ob
 }
 
 // Quick access to best prices - these get called a lot
 func (ob *OrderBook) BestBid() (price, qty float64, ok bool) {
-	ob.mu.RLock()
-	defer ob.mu.RUnlock()
-	return ob.bids.best()
+	return ob.state.Load().bids.best()
 }
 
 func (ob *OrderBook) BestAsk() (price, qty float64, ok bool) {
-	ob.mu.RLock()
-	defer ob.mu.RUnlock()
-	return ob.asks.best()
+	return ob.state.Load().asks.best()
 }
 
+// Side indicates which side of the book an order/trade sits on, from the
+// taker's perspective: a buy consumes asks, a sell consumes bids.
+type Side int
+
+const (
+	SideBuy Side = iota
+	SideSell
+)
+
 var ErrGap = errors.New("sequence gap detected")
 
 func (ob *OrderBook) GetLastID() int64 {
-	ob.mu.RLock()
-	defer ob.mu.RUnlock()
-	return ob.lastID
+	return ob.state.Load().lastID
 }
 
 type Level struct {
@@ -137,16 +167,14 @@ type Level struct {
 
 // GetBids returns top bid levels in price order (highest first)
 func (ob *OrderBook) GetBids(maxLevels int) []Level {
-	ob.mu.RLock()
-	defer ob.mu.RUnlock()
-
-	count := min(len(ob.bids.prices), maxLevels)
+	bids := ob.state.Load().bids
+	count := min(len(bids.prices), maxLevels)
 	levels := make([]Level, count)
 	for i := 0; i < count; i++ {
-		price := ob.bids.prices[i]
+		price := bids.prices[i]
 		levels[i] = Level{
 			Price:    price,
-			Quantity: ob.bids.qtyAt[price],
+			Quantity: bids.qtyAt[price],
 		}
 	}
 	return levels
@@ -154,21 +182,48 @@ func (ob *OrderBook) GetBids(maxLevels int) []Level {
 
 // GetAsks returns top ask levels in price order (lowest first)
 func (ob *OrderBook) GetAsks(maxLevels int) []Level {
-	ob.mu.RLock()
-	defer ob.mu.RUnlock()
-
-	count := min(len(ob.asks.prices), maxLevels)
+	asks := ob.state.Load().asks
+	count := min(len(asks.prices), maxLevels)
 	levels := make([]Level, count)
 	for i := 0; i < count; i++ {
-		price := ob.asks.prices[i]
+		price := asks.prices[i]
 		levels[i] = Level{
 			Price:    price,
-			Quantity: ob.asks.qtyAt[price],
+			Quantity: asks.qtyAt[price],
 		}
 	}
 	return levels
 }
 
+// BookSnapshot is an immutable, frozen view of the book returned by
+// Snapshot. Callers can traverse Bids/Asks without ever touching ob's
+// lock, mirroring the bookState readers already load internally.
+type BookSnapshot struct {
+	Bids   []Level
+	Asks   []Level
+	LastID int64
+}
+
+// Snapshot returns a frozen, lock-free view of the book as of now.
+func (ob *OrderBook) Snapshot() *BookSnapshot {
+	s := ob.state.Load()
+	return &BookSnapshot{
+		Bids:   levelsFromMap(s.bids),
+		Asks:   levelsFromMap(s.asks),
+		LastID: s.lastID,
+	}
+}
+
+// levelsFromMap snapshots every level in lm as a []Level, in lm's sort
+// order.
+func levelsFromMap(lm *levelMap) []Level {
+	levels := make([]Level, len(lm.prices))
+	for i, p := range lm.prices {
+		levels[i] = Level{Price: p, Quantity: lm.qtyAt[p]}
+	}
+	return levels
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
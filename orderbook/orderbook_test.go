@@ -3,7 +3,9 @@ package orderbook
 import (
 	"encoding/json"
 	"os"
+	"sync"
 	"testing"
+	"time"
 )
 
 func loadSnapshot(t *testing.T, f string) SnapshotMsg {
@@ -44,3 +46,266 @@ func TestSnapshotAndUpdate(t *testing.T) {
 		t.Fatalf("best bid not updated; got %.2f", p)
 	}
 }
+
+func TestSnapshotIsFrozen(t *testing.T) {
+	ob := New()
+	snap := loadSnapshot(t, "testdata/snapshot.json")
+	if err := ob.ApplySnapshot(snap); err != nil {
+		t.Fatalf("ApplySnapshot: %v", err)
+	}
+
+	view := ob.Snapshot()
+	if len(view.Bids) == 0 || view.Bids[0].Price != 10000.0 {
+		t.Fatalf("expected frozen view's best bid to be 10000, got %+v", view.Bids)
+	}
+
+	// Mutating the book afterwards must not affect the already-taken view.
+	upd := UpdateMsg{
+		FinalID: snap.LastUpdateID + 1,
+		Bids:    [][]string{{"10000.0", "0"}},
+	}
+	if err := ob.ApplyUpdate(upd); err != nil {
+		t.Fatalf("ApplyUpdate: %v", err)
+	}
+	if view.Bids[0].Price != 10000.0 {
+		t.Fatalf("frozen snapshot view mutated after a later update: %+v", view.Bids)
+	}
+	if p, _, _ := ob.BestBid(); p != 9999.50 {
+		t.Fatalf("expected live book to reflect the update, got %.2f", p)
+	}
+}
+
+func TestEventHooks(t *testing.T) {
+	ob := New()
+	snap := loadSnapshot(t, "testdata/snapshot.json")
+
+	var gotSnapshot SnapshotView
+	ob.OnSnapshot(func(v SnapshotView) { gotSnapshot = v })
+
+	var gotUpdate UpdateView
+	updateFired := 0
+	ob.OnUpdate(func(v UpdateView) {
+		gotUpdate = v
+		updateFired++
+	})
+
+	bestFired := 0
+	var lastBid, lastAsk float64
+	ob.OnBestPriceChanged(func(bid, ask float64) {
+		bestFired++
+		lastBid, lastAsk = bid, ask
+	})
+
+	if err := ob.ApplySnapshot(snap); err != nil {
+		t.Fatalf("ApplySnapshot: %v", err)
+	}
+	if len(gotSnapshot.Bids) == 0 || len(gotSnapshot.Asks) == 0 {
+		t.Fatalf("expected OnSnapshot to fire with populated levels")
+	}
+	if bestFired != 1 {
+		t.Fatalf("expected OnBestPriceChanged to fire once after snapshot, fired %d times", bestFired)
+	}
+
+	// Remove the best bid: should report a change and fire best-price.
+	upd := UpdateMsg{
+		FinalID: snap.LastUpdateID + 1,
+		Bids:    [][]string{{"10000.0", "0"}},
+	}
+	if err := ob.ApplyUpdate(upd); err != nil {
+		t.Fatalf("ApplyUpdate: %v", err)
+	}
+	if updateFired != 1 {
+		t.Fatalf("expected OnUpdate to fire once, fired %d times", updateFired)
+	}
+	if len(gotUpdate.BidChanges) != 1 || gotUpdate.BidChanges[0].Type != LevelRemoved {
+		t.Fatalf("expected a single bid removal, got %+v", gotUpdate.BidChanges)
+	}
+	if !gotUpdate.TopChanged {
+		t.Fatalf("expected TopChanged to be true after removing the best bid")
+	}
+	if bestFired != 2 {
+		t.Fatalf("expected OnBestPriceChanged to fire again, fired %d times", bestFired)
+	}
+	if lastBid != 9999.50 {
+		t.Fatalf("expected new best bid 9999.50, got %.2f", lastBid)
+	}
+	_ = lastAsk
+}
+
+func TestOnBestPriceChangedThrottled(t *testing.T) {
+	ob := New()
+	snap := loadSnapshot(t, "testdata/snapshot.json")
+
+	var mu sync.Mutex
+	fired := 0
+	ob.OnBestPriceChangedThrottled(50*time.Millisecond, func(bid, ask float64) {
+		mu.Lock()
+		fired++
+		mu.Unlock()
+	})
+
+	if err := ob.ApplySnapshot(snap); err != nil {
+		t.Fatalf("ApplySnapshot: %v", err)
+	}
+
+	// Two more best-price moves in quick succession should coalesce into
+	// at most one extra notification instead of firing for each.
+	for _, price := range []string{"10000.0", "9999.50"} {
+		if err := ob.ApplyUpdate(UpdateMsg{
+			FinalID: ob.GetLastID() + 1,
+			Bids:    [][]string{{price, "0"}},
+		}); err != nil {
+			t.Fatalf("ApplyUpdate: %v", err)
+		}
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	got := fired
+	mu.Unlock()
+	if got < 1 || got > 2 {
+		t.Fatalf("expected 1-2 coalesced notifications, got %d", got)
+	}
+}
+
+func TestOnBestPriceChangedThrottledUnsubscribe(t *testing.T) {
+	ob := New()
+	snap := loadSnapshot(t, "testdata/snapshot.json")
+	if err := ob.ApplySnapshot(snap); err != nil {
+		t.Fatalf("ApplySnapshot: %v", err)
+	}
+
+	var mu sync.Mutex
+	fired := 0
+	unsubscribe := ob.OnBestPriceChangedThrottled(time.Millisecond, func(bid, ask float64) {
+		mu.Lock()
+		fired++
+		mu.Unlock()
+	})
+
+	if err := ob.ApplyUpdate(UpdateMsg{FinalID: ob.GetLastID() + 1, Bids: [][]string{{"10000.0", "0"}}}); err != nil {
+		t.Fatalf("ApplyUpdate: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	mu.Lock()
+	before := fired
+	mu.Unlock()
+	if before == 0 {
+		t.Fatalf("expected at least one notification before unsubscribing")
+	}
+
+	unsubscribe()
+	ob.handlers.mu.Lock()
+	remaining := len(ob.handlers.onBestPriceThrott)
+	ob.handlers.mu.Unlock()
+	if remaining != 0 {
+		t.Fatalf("expected unsubscribe to remove the handler, got %d remaining", remaining)
+	}
+
+	if err := ob.ApplyUpdate(UpdateMsg{FinalID: ob.GetLastID() + 1, Bids: [][]string{{"9999.50", "0"}}}); err != nil {
+		t.Fatalf("ApplyUpdate: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	mu.Lock()
+	after := fired
+	mu.Unlock()
+	if after != before {
+		t.Fatalf("expected no further notifications after unsubscribe, fired went from %d to %d", before, after)
+	}
+}
+
+// TestThrottledHandlerDeliversLatestValues covers throttledHandler.notify
+// directly: a burst of calls inside minInterval must coalesce down to the
+// last call's values, not whichever call happened to schedule the timer.
+func TestThrottledHandlerDeliversLatestValues(t *testing.T) {
+	var mu sync.Mutex
+	var gotBid, gotAsk float64
+	fired := 0
+
+	th := &throttledHandler{
+		minInterval: 50 * time.Millisecond,
+		fn: func(bid, ask float64) {
+			mu.Lock()
+			fired++
+			gotBid, gotAsk = bid, ask
+			mu.Unlock()
+		},
+	}
+
+	th.notify(1, 2) // fires immediately, starts the window
+	th.notify(3, 4) // coalesced: schedules the timer
+	th.notify(5, 6) // coalesced: must overwrite the timer's pending values
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if fired != 2 {
+		t.Fatalf("expected exactly 2 notifications, got %d", fired)
+	}
+	if gotBid != 5 || gotAsk != 6 {
+		t.Fatalf("expected the coalesced notification to carry the latest values (5, 6), got (%v, %v)", gotBid, gotAsk)
+	}
+}
+
+func TestPriceForQuantity(t *testing.T) {
+	ob := New()
+	snap := loadSnapshot(t, "testdata/snapshot.json")
+	if err := ob.ApplySnapshot(snap); err != nil {
+		t.Fatalf("ApplySnapshot: %v", err)
+	}
+
+	// Asks: 10010.00 x1.2, 10010.50 x3.0, 10011.00 x0.5
+	avg, filled, ok := ob.PriceForQuantity(SideBuy, 2.0)
+	if !ok {
+		t.Fatalf("expected full fill")
+	}
+	if filled != 2.0 {
+		t.Fatalf("expected filled 2.0, got %.4f", filled)
+	}
+	wantCost := 10010.00*1.2 + 10010.50*0.8
+	wantAvg := wantCost / 2.0
+	if avg < wantAvg-0.001 || avg > wantAvg+0.001 {
+		t.Fatalf("expected avg price ~%.4f, got %.4f", wantAvg, avg)
+	}
+
+	// More than the book can fill: partial fill, ok=false
+	_, filled, ok = ob.PriceForQuantity(SideBuy, 100.0)
+	if ok {
+		t.Fatalf("expected partial fill")
+	}
+	if filled != 1.2+3.0+0.5 {
+		t.Fatalf("expected filled to equal total ask depth, got %.4f", filled)
+	}
+}
+
+func TestQuantityForPrice(t *testing.T) {
+	ob := New()
+	snap := loadSnapshot(t, "testdata/snapshot.json")
+	if err := ob.ApplySnapshot(snap); err != nil {
+		t.Fatalf("ApplySnapshot: %v", err)
+	}
+
+	qty := ob.QuantityForPrice(SideBuy, 10010.50)
+	if qty != 1.2+3.0 {
+		t.Fatalf("expected 4.2 available at/below 10010.50, got %.4f", qty)
+	}
+}
+
+func TestDepthPrice(t *testing.T) {
+	ob := New()
+	snap := loadSnapshot(t, "testdata/snapshot.json")
+	if err := ob.ApplySnapshot(snap); err != nil {
+		t.Fatalf("ApplySnapshot: %v", err)
+	}
+
+	if p, ok := ob.DepthPrice(SideSell, 1); !ok || p != 9999.50 {
+		t.Fatalf("expected level 1 bid 9999.50, got %.2f ok=%v", p, ok)
+	}
+	if _, ok := ob.DepthPrice(SideSell, 99); ok {
+		t.Fatalf("expected out-of-range level to report ok=false")
+	}
+}
@@ -14,19 +14,22 @@ type SnapshotMsg struct {
 
 // ApplySnapshot loads a fresh snapshot, replacing everything
 func (ob *OrderBook) ApplySnapshot(snap SnapshotMsg) error {
-	ob.mu.Lock()
-	defer ob.mu.Unlock()
+	ob.writeMu.Lock()
 
-	// Create new level maps with the right capacity
+	old := ob.state.Load()
+	oldBidPrice, _, oldHasBid := old.bids.best()
+	oldAskPrice, _, oldHasAsk := old.asks.best()
+
+	// Build fresh level maps with the right capacity
 	bidCount := len(snap.Bids)
 	askCount := len(snap.Asks)
 
-	ob.bids = &levelMap{
+	newBids := &levelMap{
 		isBid:  true,
 		prices: make([]float64, 0, bidCount),
 		qtyAt:  make(map[float64]float64, bidCount),
 	}
-	ob.asks = &levelMap{
+	newAsks := &levelMap{
 		isBid:  false,
 		prices: make([]float64, 0, askCount),
 		qtyAt:  make(map[float64]float64, askCount),
@@ -38,15 +41,16 @@ func (ob *OrderBook) ApplySnapshot(snap SnapshotMsg) error {
 		price, err1 := strconv.ParseFloat(lvl[0], 64)
 		qty, err2 := strconv.ParseFloat(lvl[1], 64)
 		if err1 != nil || err2 != nil {
+			ob.writeMu.Unlock()
 			return err1
 		}
 		if qty > 0 {
-			ob.bids.qtyAt[price] = qty
+			newBids.qtyAt[price] = qty
 			bidPrices = append(bidPrices, price)
 		}
 	}
 	sort.Sort(sort.Reverse(sort.Float64Slice(bidPrices)))
-	ob.bids.prices = bidPrices
+	newBids.prices = bidPrices
 
 	// Same for asks
 	askPrices := make([]float64, 0, askCount)
@@ -54,16 +58,38 @@ func (ob *OrderBook) ApplySnapshot(snap SnapshotMsg) error {
 		price, err1 := strconv.ParseFloat(lvl[0], 64)
 		qty, err2 := strconv.ParseFloat(lvl[1], 64)
 		if err1 != nil || err2 != nil {
+			ob.writeMu.Unlock()
 			return err1
 		}
 		if qty > 0 {
-			ob.asks.qtyAt[price] = qty
+			newAsks.qtyAt[price] = qty
 			askPrices = append(askPrices, price)
 		}
 	}
 	sort.Float64s(askPrices)
-	ob.asks.prices = askPrices
+	newAsks.prices = askPrices
+
+	ob.state.Store(&bookState{
+		lastID: snap.LastUpdateID,
+		bids:   newBids,
+		asks:   newAsks,
+	})
+
+	view := SnapshotView{
+		Bids:         levelsFromMap(newBids),
+		Asks:         levelsFromMap(newAsks),
+		LastUpdateID: snap.LastUpdateID,
+	}
+	newBidPrice, _, newHasBid := newBids.best()
+	newAskPrice, _, newHasAsk := newAsks.best()
+	topChanged := oldHasBid != newHasBid || oldHasAsk != newHasAsk ||
+		oldBidPrice != newBidPrice || oldAskPrice != newAskPrice
+
+	ob.writeMu.Unlock()
 
-	ob.lastID = snap.LastUpdateID
+	ob.dispatchSnapshot(view)
+	if topChanged && newHasBid && newHasAsk {
+		ob.dispatchBestPriceChanged(newBidPrice, newAskPrice)
+	}
 	return nil
 }
@@ -12,39 +12,90 @@ type UpdateMsg struct {
 
 // ApplyUpdate applies an incremental update to the order book
 func (ob *OrderBook) ApplyUpdate(upd UpdateMsg) error {
-	ob.mu.Lock()
-	defer ob.mu.Unlock()
+	ob.writeMu.Lock()
+
+	old := ob.state.Load()
 
 	// Skip old/duplicate updates
-	if upd.FinalID < ob.lastID+1 {
+	if upd.FinalID < old.lastID+1 {
+		ob.writeMu.Unlock()
 		return nil
 	}
 
 	// Check for gaps in sequence
-	if ob.lastID+1 < upd.FirstID || upd.FinalID < ob.lastID+1 {
+	if old.lastID+1 < upd.FirstID || upd.FinalID < old.lastID+1 {
+		ob.writeMu.Unlock()
 		return ErrGap
 	}
 
-	// Helper to parse price levels
-	parseLevels := func(levels [][]string, m *levelMap) error {
+	oldBidPrice, _, oldHasBid := old.bids.best()
+	oldAskPrice, _, oldHasAsk := old.asks.best()
+
+	// Work on copies so any bookState a reader is still holding stays
+	// untouched until the new state is published.
+	newBids := old.bids.clone()
+	newAsks := old.asks.clone()
+
+	// Helper to parse price levels and record what changed at each one
+	parseLevels := func(levels [][]string, m *levelMap) ([]LevelChange, error) {
+		var changes []LevelChange
 		for _, lvl := range levels {
 			price, err1 := strconv.ParseFloat(lvl[0], 64)
 			qty, err2 := strconv.ParseFloat(lvl[1], 64)
 			if err1 != nil || err2 != nil {
-				return err1
+				return nil, err1
 			}
+			oldQty, existed := m.qtyAt[price]
 			m.set(price, qty)
+
+			switch {
+			case qty == 0:
+				if existed {
+					changes = append(changes, LevelChange{Price: price, Quantity: oldQty, Type: LevelRemoved})
+				}
+			case !existed:
+				changes = append(changes, LevelChange{Price: price, Quantity: qty, Type: LevelAdded})
+			case oldQty != qty:
+				changes = append(changes, LevelChange{Price: price, Quantity: qty, Type: LevelModified})
+			}
 		}
-		return nil
+		return changes, nil
 	}
 
-	if err := parseLevels(upd.Bids, ob.bids); err != nil {
+	bidChanges, err := parseLevels(upd.Bids, newBids)
+	if err != nil {
+		ob.writeMu.Unlock()
 		return err
 	}
-	if err := parseLevels(upd.Asks, ob.asks); err != nil {
+	askChanges, err := parseLevels(upd.Asks, newAsks)
+	if err != nil {
+		ob.writeMu.Unlock()
 		return err
 	}
 
-	ob.lastID = upd.FinalID
+	ob.state.Store(&bookState{
+		lastID: upd.FinalID,
+		bids:   newBids,
+		asks:   newAsks,
+	})
+
+	newBidPrice, _, newHasBid := newBids.best()
+	newAskPrice, _, newHasAsk := newAsks.best()
+	topChanged := oldHasBid != newHasBid || oldHasAsk != newHasAsk ||
+		oldBidPrice != newBidPrice || oldAskPrice != newAskPrice
+
+	ob.writeMu.Unlock()
+
+	if len(bidChanges) > 0 || len(askChanges) > 0 {
+		ob.dispatchUpdate(UpdateView{
+			BidChanges:   bidChanges,
+			AskChanges:   askChanges,
+			LastUpdateID: upd.FinalID,
+			TopChanged:   topChanged,
+		})
+	}
+	if topChanged && newHasBid && newHasAsk {
+		ob.dispatchBestPriceChanged(newBidPrice, newAskPrice)
+	}
 	return nil
 }
@@ -0,0 +1,90 @@
+// Package binance implements wsclient.Exchange against Binance's public
+// REST depth snapshot and WebSocket diff-depth stream.
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"raven-dev-challenge/orderbook"
+
+	"github.com/gorilla/websocket"
+)
+
+// Adapter talks to Binance's spot market data endpoints. Symbol is
+// expected in Binance's own form, e.g. "BTCUSDT" (case-insensitive).
+type Adapter struct{}
+
+// New returns a Binance Adapter.
+func New() *Adapter {
+	return &Adapter{}
+}
+
+func (a *Adapter) Name() string { return "binance" }
+
+// FetchSnapshot grabs a depth snapshot from Binance's REST API.
+func (a *Adapter) FetchSnapshot(ctx context.Context, symbol string) (orderbook.SnapshotMsg, error) {
+	url := fmt.Sprintf("https://api.binance.com/api/v3/depth?symbol=%s&limit=1000", strings.ToUpper(symbol))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return orderbook.SnapshotMsg{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return orderbook.SnapshotMsg{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return orderbook.SnapshotMsg{}, fmt.Errorf("snapshot HTTP %d for %s", resp.StatusCode, symbol)
+	}
+
+	var snap orderbook.SnapshotMsg
+	if err := json.NewDecoder(resp.Body).Decode(&snap); err != nil {
+		return orderbook.SnapshotMsg{}, err
+	}
+	return snap, nil
+}
+
+// SubscribeDepth connects to Binance's diff-depth WebSocket stream and
+// decodes each message into an orderbook.UpdateMsg.
+func (a *Adapter) SubscribeDepth(ctx context.Context, symbol string) (<-chan orderbook.UpdateMsg, error) {
+	url := fmt.Sprintf("wss://stream.binance.com:9443/ws/%s@depth@100ms", strings.ToLower(symbol))
+	ws, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan orderbook.UpdateMsg)
+	go func() {
+		defer close(out)
+		defer ws.Close()
+
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+			ws.SetReadDeadline(time.Now().Add(10 * time.Second))
+			_, msg, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			var upd orderbook.UpdateMsg
+			if err := json.Unmarshal(msg, &upd); err != nil {
+				continue
+			}
+
+			select {
+			case out <- upd:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,160 @@
+// Package coinbase implements wsclient.Exchange against Coinbase
+// Exchange's public REST order book endpoint and "level2" WebSocket
+// channel.
+//
+// Coinbase's level2 feed doesn't expose a monotonic sequence number
+// comparable across the REST snapshot and the WebSocket stream the way
+// Binance's U/u fields do - each l2update message is just an ordered
+// set of changes. This adapter assigns its own monotonically increasing
+// IDs to incoming updates (reset per connection) and reports a
+// LastUpdateID of 0 from FetchSnapshot, so wsclient.Manager's normal
+// "skip updates older than the snapshot" logic is a no-op here: on
+// Coinbase, ordering within one WebSocket connection is the only
+// guarantee, and gap detection degrades to "did the connection drop"
+// rather than "did we miss a sequence number".
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"raven-dev-challenge/orderbook"
+
+	"github.com/gorilla/websocket"
+)
+
+const wsURL = "wss://ws-feed.exchange.coinbase.com"
+
+// Adapter talks to Coinbase Exchange's public market data endpoints.
+// Symbol is expected in Coinbase's own product ID form, e.g. "BTC-USD".
+type Adapter struct{}
+
+// New returns a Coinbase Adapter.
+func New() *Adapter {
+	return &Adapter{}
+}
+
+func (a *Adapter) Name() string { return "coinbase" }
+
+type restBook struct {
+	Bids [][]string `json:"bids"`
+	Asks [][]string `json:"asks"`
+}
+
+// FetchSnapshot grabs a level-2 order book from Coinbase's REST API.
+func (a *Adapter) FetchSnapshot(ctx context.Context, productID string) (orderbook.SnapshotMsg, error) {
+	url := fmt.Sprintf("https://api.exchange.coinbase.com/products/%s/book?level=2", productID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return orderbook.SnapshotMsg{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return orderbook.SnapshotMsg{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return orderbook.SnapshotMsg{}, fmt.Errorf("snapshot HTTP %d for %s", resp.StatusCode, productID)
+	}
+
+	var book restBook
+	if err := json.NewDecoder(resp.Body).Decode(&book); err != nil {
+		return orderbook.SnapshotMsg{}, err
+	}
+
+	// See the package doc comment: Coinbase has no sequence number that
+	// lines up with the WebSocket stream, so LastUpdateID stays 0.
+	return orderbook.SnapshotMsg{Bids: book.Bids, Asks: book.Asks}, nil
+}
+
+type l2Message struct {
+	Type    string     `json:"type"`
+	Changes [][]string `json:"changes"` // each entry: [side, price, size]
+}
+
+// SubscribeDepth subscribes to Coinbase's level2 channel and decodes
+// each l2update message into an orderbook.UpdateMsg.
+func (a *Adapter) SubscribeDepth(ctx context.Context, productID string) (<-chan orderbook.UpdateMsg, error) {
+	ws, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := map[string]any{
+		"type":        "subscribe",
+		"product_ids": []string{productID},
+		"channels":    []string{"level2"},
+	}
+	if err := ws.WriteJSON(sub); err != nil {
+		ws.Close()
+		return nil, err
+	}
+
+	out := make(chan orderbook.UpdateMsg)
+	go func() {
+		defer close(out)
+		defer ws.Close()
+
+		var seq int64
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+			ws.SetReadDeadline(time.Now().Add(10 * time.Second))
+			_, msg, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			upd, ok := parseL2Update(msg)
+			if !ok {
+				continue // "snapshot" and other channel messages are not updates
+			}
+			seq++
+			upd.FirstID, upd.FinalID = seq, seq
+
+			select {
+			case out <- upd:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// parseL2Update decodes one Coinbase level2 channel message into an
+// UpdateMsg. Only "l2update" messages carry book changes; "snapshot" and
+// any other channel message, or a change entry that doesn't carry all
+// three [side, price, size] fields, are skipped.
+func parseL2Update(msg []byte) (orderbook.UpdateMsg, bool) {
+	var env l2Message
+	if err := json.Unmarshal(msg, &env); err != nil {
+		return orderbook.UpdateMsg{}, false
+	}
+	if env.Type != "l2update" {
+		return orderbook.UpdateMsg{}, false
+	}
+
+	var bids, asks [][]string
+	for _, change := range env.Changes {
+		if len(change) != 3 {
+			continue
+		}
+		side, price, size := change[0], change[1], change[2]
+		lvl := []string{price, size}
+		if side == "buy" {
+			bids = append(bids, lvl)
+		} else {
+			asks = append(asks, lvl)
+		}
+	}
+	if len(bids) == 0 && len(asks) == 0 {
+		return orderbook.UpdateMsg{}, false
+	}
+	return orderbook.UpdateMsg{Bids: bids, Asks: asks}, true
+}
@@ -0,0 +1,75 @@
+package coinbase
+
+import "testing"
+
+func TestParseL2Update(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		wantOK   bool
+		wantBids [][]string
+		wantAsks [][]string
+	}{
+		{
+			name:     "buy and sell changes",
+			raw:      `{"type":"l2update","product_id":"BTC-USD","changes":[["buy","10101.10","0.45054140"],["sell","10102.55","0.57753524"]]}`,
+			wantOK:   true,
+			wantBids: [][]string{{"10101.10", "0.45054140"}},
+			wantAsks: [][]string{{"10102.55", "0.57753524"}},
+		},
+		{
+			name:   "snapshot message is ignored",
+			raw:    `{"type":"snapshot","product_id":"BTC-USD","bids":[["10101.10","0.45054140"]],"asks":[["10102.55","0.57753524"]]}`,
+			wantOK: false,
+		},
+		{
+			name:   "subscriptions confirmation message is ignored",
+			raw:    `{"type":"subscriptions","channels":[{"name":"level2","product_ids":["BTC-USD"]}]}`,
+			wantOK: false,
+		},
+		{
+			name:   "change entry missing a field is skipped",
+			raw:    `{"type":"l2update","product_id":"BTC-USD","changes":[["buy","10101.10"]]}`,
+			wantOK: false,
+		},
+		{
+			name:     "multiple changes on the same side accumulate",
+			raw:      `{"type":"l2update","product_id":"BTC-USD","changes":[["buy","10101.10","0.45054140"],["buy","10100.00","0.00000000"]]}`,
+			wantOK:   true,
+			wantBids: [][]string{{"10101.10", "0.45054140"}, {"10100.00", "0.00000000"}},
+		},
+		{
+			name:   "malformed JSON",
+			raw:    `{"type":"l2update","changes":`,
+			wantOK: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := parseL2Update([]byte(tc.raw))
+			if ok != tc.wantOK {
+				t.Fatalf("parseL2Update() ok = %v, want %v", ok, tc.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if len(got.Bids) != len(tc.wantBids) {
+				t.Fatalf("Bids = %v, want %v", got.Bids, tc.wantBids)
+			}
+			for i := range got.Bids {
+				if got.Bids[i][0] != tc.wantBids[i][0] || got.Bids[i][1] != tc.wantBids[i][1] {
+					t.Fatalf("Bids[%d] = %v, want %v", i, got.Bids[i], tc.wantBids[i])
+				}
+			}
+			if len(got.Asks) != len(tc.wantAsks) {
+				t.Fatalf("Asks = %v, want %v", got.Asks, tc.wantAsks)
+			}
+			for i := range got.Asks {
+				if got.Asks[i][0] != tc.wantAsks[i][0] || got.Asks[i][1] != tc.wantAsks[i][1] {
+					t.Fatalf("Asks[%d] = %v, want %v", i, got.Asks[i], tc.wantAsks[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Package wsclient consolidates market-data feeds from multiple
+// exchanges into orderbook.OrderBook instances. It defines the Exchange
+// interface that per-exchange adapters (wsclient/binance,
+// wsclient/coinbase, wsclient/kraken, ...) implement, and a Manager that
+// owns one OrderBook per configured (exchange, symbol) pair with its own
+// independent reconnect/backoff loop - analogous to bbgo's per-exchange
+// session model, where a book is bound to any exchange via a shared
+// set of types.
+package wsclient
+
+import (
+	"context"
+
+	"raven-dev-challenge/orderbook"
+)
+
+// Exchange is a pluggable market-data source: given a symbol (in
+// whatever form that exchange's own API expects, e.g. "BTCUSDT" for
+// Binance or "BTC-USD" for Coinbase), it can fetch a REST depth snapshot
+// and stream incremental depth updates. Adapters translate each
+// exchange's own wire format into the shared orderbook.SnapshotMsg and
+// orderbook.UpdateMsg types.
+type Exchange interface {
+	// Name identifies the exchange, e.g. "binance".
+	Name() string
+
+	// FetchSnapshot fetches a full depth snapshot for symbol.
+	FetchSnapshot(ctx context.Context, symbol string) (orderbook.SnapshotMsg, error)
+
+	// SubscribeDepth opens a live incremental depth stream for symbol.
+	// The returned channel is closed when the stream ends - context
+	// cancellation, a dropped connection, or a decode failure the
+	// adapter can't recover from - at which point the caller should
+	// reconnect by calling SubscribeDepth again.
+	SubscribeDepth(ctx context.Context, symbol string) (<-chan orderbook.UpdateMsg, error)
+}
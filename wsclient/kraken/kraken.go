@@ -0,0 +1,197 @@
+// Package kraken implements wsclient.Exchange against Kraken's public
+// REST order book endpoint and "book" WebSocket subscription.
+//
+// Like Coinbase, Kraken's book feed has no sequence number comparable
+// across the REST snapshot and the WebSocket stream (Kraken instead
+// relies on a per-message CRC32 checksum, which this adapter doesn't
+// verify). IDs assigned to updates are a local, per-connection counter;
+// see wsclient/coinbase for the same tradeoff.
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"raven-dev-challenge/orderbook"
+
+	"github.com/gorilla/websocket"
+)
+
+const wsURL = "wss://ws.kraken.com"
+
+// Adapter talks to Kraken's public market data endpoints. Pair is
+// expected in Kraken's own form, e.g. "XBT/USD".
+type Adapter struct{}
+
+// New returns a Kraken Adapter.
+func New() *Adapter {
+	return &Adapter{}
+}
+
+func (a *Adapter) Name() string { return "kraken" }
+
+type depthResponse struct {
+	Error  []string `json:"error"`
+	Result map[string]struct {
+		Asks [][]json.RawMessage `json:"asks"`
+		Bids [][]json.RawMessage `json:"bids"`
+	} `json:"result"`
+}
+
+// FetchSnapshot grabs an order book from Kraken's public Depth endpoint.
+func (a *Adapter) FetchSnapshot(ctx context.Context, pair string) (orderbook.SnapshotMsg, error) {
+	url := fmt.Sprintf("https://api.kraken.com/0/public/Depth?pair=%s", pair)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return orderbook.SnapshotMsg{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return orderbook.SnapshotMsg{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return orderbook.SnapshotMsg{}, fmt.Errorf("snapshot HTTP %d for %s", resp.StatusCode, pair)
+	}
+
+	var parsed depthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return orderbook.SnapshotMsg{}, err
+	}
+	if len(parsed.Error) > 0 {
+		return orderbook.SnapshotMsg{}, fmt.Errorf("kraken error: %v", parsed.Error)
+	}
+
+	// Kraken keys the result by its own asset pair name (e.g. "XXBTZUSD"),
+	// not the altname we requested with, and only one pair was requested.
+	for _, book := range parsed.Result {
+		return orderbook.SnapshotMsg{
+			Bids: krakenLevels(book.Bids),
+			Asks: krakenLevels(book.Asks),
+		}, nil
+	}
+	return orderbook.SnapshotMsg{}, fmt.Errorf("no depth result for pair %s", pair)
+}
+
+// krakenLevels converts Kraken's [price, volume, timestamp] triplets
+// (mixed string/number types) into the [price, volume] string pairs
+// orderbook.SnapshotMsg expects.
+func krakenLevels(raw [][]json.RawMessage) [][]string {
+	levels := make([][]string, 0, len(raw))
+	for _, lvl := range raw {
+		if len(lvl) < 2 {
+			continue
+		}
+		var price, volume string
+		if err := json.Unmarshal(lvl[0], &price); err != nil {
+			continue
+		}
+		if err := json.Unmarshal(lvl[1], &volume); err != nil {
+			continue
+		}
+		levels = append(levels, []string{price, volume})
+	}
+	return levels
+}
+
+// SubscribeDepth subscribes to Kraken's "book" channel and decodes each
+// incremental update message into an orderbook.UpdateMsg.
+func (a *Adapter) SubscribeDepth(ctx context.Context, pair string) (<-chan orderbook.UpdateMsg, error) {
+	ws, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := map[string]any{
+		"event": "subscribe",
+		"pair":  []string{pair},
+		"subscription": map[string]any{
+			"name":  "book",
+			"depth": 100,
+		},
+	}
+	if err := ws.WriteJSON(sub); err != nil {
+		ws.Close()
+		return nil, err
+	}
+
+	out := make(chan orderbook.UpdateMsg)
+	go func() {
+		defer close(out)
+		defer ws.Close()
+
+		var seq int64
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+			ws.SetReadDeadline(time.Now().Add(10 * time.Second))
+			_, msg, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			upd, ok := parseBookMessage(msg)
+			if !ok {
+				continue // event/heartbeat message, or an initial "as"/"bs" snapshot payload
+			}
+			seq++
+			upd.FirstID, upd.FinalID = seq, seq
+
+			select {
+			case out <- upd:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// parseBookMessage decodes one of Kraken's array-framed book messages
+// into an UpdateMsg. Kraken frames every channel message as
+// [channelID, {...}, ..., channelName, pair]; this picks out the object
+// elements carrying "a" (ask) or "b" (bid) changes and ignores the
+// initial "as"/"bs" snapshot payload and non-object framing elements.
+func parseBookMessage(raw []byte) (orderbook.UpdateMsg, bool) {
+	if len(raw) == 0 || raw[0] != '[' {
+		return orderbook.UpdateMsg{}, false
+	}
+
+	var elems []json.RawMessage
+	if err := json.Unmarshal(raw, &elems); err != nil {
+		return orderbook.UpdateMsg{}, false
+	}
+
+	var bids, asks [][]string
+	for _, elem := range elems {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(elem, &obj); err != nil {
+			continue // channel ID, channel name, or pair string
+		}
+		if _, isSnapshot := obj["as"]; isSnapshot {
+			continue
+		}
+		if raw, ok := obj["a"]; ok {
+			var levels [][]json.RawMessage
+			if err := json.Unmarshal(raw, &levels); err == nil {
+				asks = append(asks, krakenLevels(levels)...)
+			}
+		}
+		if raw, ok := obj["b"]; ok {
+			var levels [][]json.RawMessage
+			if err := json.Unmarshal(raw, &levels); err == nil {
+				bids = append(bids, krakenLevels(levels)...)
+			}
+		}
+	}
+
+	if len(bids) == 0 && len(asks) == 0 {
+		return orderbook.UpdateMsg{}, false
+	}
+	return orderbook.UpdateMsg{Bids: bids, Asks: asks}, true
+}
@@ -0,0 +1,125 @@
+package kraken
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestKrakenLevels(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want [][]string
+	}{
+		{
+			name: "valid triplets",
+			raw:  `[["5541.30000","2.50700000","1534614248.456738"],["5541.80000","0.33000000","1534614098.345543"]]`,
+			want: [][]string{{"5541.30000", "2.50700000"}, {"5541.80000", "0.33000000"}},
+		},
+		{
+			name: "skips entries shorter than price+volume",
+			raw:  `[["5541.30000"],["5541.80000","0.33000000","1534614098.345543"]]`,
+			want: [][]string{{"5541.80000", "0.33000000"}},
+		},
+		{
+			name: "skips entries with non-string price or volume",
+			raw:  `[[5541.3,"2.50700000","1534614248.456738"],["5541.80000","0.33000000","1534614098.345543"]]`,
+			want: [][]string{{"5541.80000", "0.33000000"}},
+		},
+		{
+			name: "empty input",
+			raw:  `[]`,
+			want: [][]string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var raw [][]json.RawMessage
+			if err := json.Unmarshal([]byte(tc.raw), &raw); err != nil {
+				t.Fatalf("unmarshal fixture: %v", err)
+			}
+			got := krakenLevels(raw)
+			if len(got) != len(tc.want) {
+				t.Fatalf("krakenLevels() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i][0] != tc.want[i][0] || got[i][1] != tc.want[i][1] {
+					t.Fatalf("krakenLevels()[%d] = %v, want %v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseBookMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		wantOK   bool
+		wantBids [][]string
+		wantAsks [][]string
+	}{
+		{
+			name:   "initial as/bs snapshot is ignored",
+			raw:    `[336,{"as":[["5541.30000","2.50700000","1534614248.456738"]],"bs":[["5541.20000","1.52900000","1534614098.345543"]]},"book-100","XBT/USD"]`,
+			wantOK: false,
+		},
+		{
+			name:     "bid-only update",
+			raw:      `[336,{"b":[["5541.30000","0.00000000","1534614057.321597"]]},"book-100","XBT/USD"]`,
+			wantOK:   true,
+			wantBids: [][]string{{"5541.30000", "0.00000000"}},
+		},
+		{
+			name:     "ask and bid updates framed as separate objects",
+			raw:      `[336,{"a":[["5542.00000","1.00000000","1534614057.321597"]]},{"b":[["5541.30000","0.50000000","1534614057.400000"]]},"book-100","XBT/USD"]`,
+			wantOK:   true,
+			wantAsks: [][]string{{"5542.00000", "1.00000000"}},
+			wantBids: [][]string{{"5541.30000", "0.50000000"}},
+		},
+		{
+			name:   "heartbeat event message",
+			raw:    `{"event":"heartbeat"}`,
+			wantOK: false,
+		},
+		{
+			name:   "subscription status event message",
+			raw:    `{"event":"subscriptionStatus","status":"subscribed","pair":"XBT/USD"}`,
+			wantOK: false,
+		},
+		{
+			name:   "malformed JSON",
+			raw:    `[336,{"b":`,
+			wantOK: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := parseBookMessage([]byte(tc.raw))
+			if ok != tc.wantOK {
+				t.Fatalf("parseBookMessage() ok = %v, want %v", ok, tc.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if len(got.Bids) != len(tc.wantBids) {
+				t.Fatalf("Bids = %v, want %v", got.Bids, tc.wantBids)
+			}
+			for i := range got.Bids {
+				if got.Bids[i][0] != tc.wantBids[i][0] || got.Bids[i][1] != tc.wantBids[i][1] {
+					t.Fatalf("Bids[%d] = %v, want %v", i, got.Bids[i], tc.wantBids[i])
+				}
+			}
+			if len(got.Asks) != len(tc.wantAsks) {
+				t.Fatalf("Asks = %v, want %v", got.Asks, tc.wantAsks)
+			}
+			for i := range got.Asks {
+				if got.Asks[i][0] != tc.wantAsks[i][0] || got.Asks[i][1] != tc.wantAsks[i][1] {
+					t.Fatalf("Asks[%d] = %v, want %v", i, got.Asks[i], tc.wantAsks[i])
+				}
+			}
+		})
+	}
+}
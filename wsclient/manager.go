@@ -0,0 +1,282 @@
+package wsclient
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"raven-dev-challenge/orderbook"
+)
+
+const (
+	minBackoff             = time.Second
+	maxBackoff             = 30 * time.Second
+	defaultMaxResyncBuffer = 1000
+)
+
+// PairConfig pairs an Exchange adapter with the symbol to track on it.
+type PairConfig struct {
+	Exchange Exchange
+	Symbol   string
+}
+
+// pairMetrics tracks gap-recovery stats for one managed pair.
+type pairMetrics struct {
+	gapCount         atomic.Int64
+	resyncDurationMs atomic.Int64
+	bufferedUpdates  atomic.Int64
+}
+
+// PairStats is a point-in-time read of a managed pair's gap-recovery
+// metrics.
+type PairStats struct {
+	GapCount         int64
+	ResyncDurationMs int64
+	BufferedUpdates  int64
+}
+
+// Manager owns one OrderBook per configured (exchange, symbol) pair and
+// runs each pair's feed on its own independent reconnect/backoff loop,
+// so a disconnect or gap on one pair never affects the others.
+type Manager struct {
+	configs         []PairConfig
+	books           map[string]*orderbook.OrderBook
+	metrics         map[string]*pairMetrics
+	maxResyncBuffer int
+}
+
+// NewManager builds a Manager for the given pairs, creating one fresh
+// OrderBook per pair. maxResyncBuffer bounds how many updates are
+// buffered per pair while a gap-recovery snapshot is in flight; 0 means
+// use defaultMaxResyncBuffer.
+func NewManager(configs []PairConfig, maxResyncBuffer int) *Manager {
+	if maxResyncBuffer <= 0 {
+		maxResyncBuffer = defaultMaxResyncBuffer
+	}
+	m := &Manager{
+		configs:         configs,
+		books:           make(map[string]*orderbook.OrderBook, len(configs)),
+		metrics:         make(map[string]*pairMetrics, len(configs)),
+		maxResyncBuffer: maxResyncBuffer,
+	}
+	for _, cfg := range configs {
+		key := pairKey(cfg.Exchange.Name(), cfg.Symbol)
+		m.books[key] = orderbook.New()
+		m.metrics[key] = &pairMetrics{}
+	}
+	return m
+}
+
+func pairKey(exchangeName, symbol string) string {
+	return exchangeName + ":" + strings.ToUpper(symbol)
+}
+
+// Book returns the OrderBook tracking exchangeName/symbol, if configured.
+func (m *Manager) Book(exchangeName, symbol string) (*orderbook.OrderBook, bool) {
+	ob, ok := m.books[pairKey(exchangeName, symbol)]
+	return ob, ok
+}
+
+// Books returns every managed OrderBook, keyed by "exchange:SYMBOL".
+func (m *Manager) Books() map[string]*orderbook.OrderBook {
+	return m.books
+}
+
+// Stats returns the gap-recovery metrics for exchangeName/symbol, if
+// configured.
+func (m *Manager) Stats(exchangeName, symbol string) (PairStats, bool) {
+	metrics, ok := m.metrics[pairKey(exchangeName, symbol)]
+	if !ok {
+		return PairStats{}, false
+	}
+	return PairStats{
+		GapCount:         metrics.gapCount.Load(),
+		ResyncDurationMs: metrics.resyncDurationMs.Load(),
+		BufferedUpdates:  metrics.bufferedUpdates.Load(),
+	}, true
+}
+
+// Run starts one reconnect/backoff loop per configured pair and blocks
+// until ctx is cancelled.
+func (m *Manager) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, cfg := range m.configs {
+		cfg := cfg
+		ob := m.books[pairKey(cfg.Exchange.Name(), cfg.Symbol)]
+		metrics := m.metrics[pairKey(cfg.Exchange.Name(), cfg.Symbol)]
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runPair(ctx, cfg.Exchange, cfg.Symbol, ob, metrics, m.maxResyncBuffer)
+		}()
+	}
+	wg.Wait()
+}
+
+// runPair fetches a snapshot, subscribes to the depth stream, and keeps
+// reconnecting with exponential backoff whenever the stream fails,
+// until ctx is cancelled.
+func runPair(ctx context.Context, exch Exchange, symbol string, ob *orderbook.OrderBook, metrics *pairMetrics, maxResyncBuffer int) {
+	backoff := minBackoff
+	for ctx.Err() == nil {
+		if err := runSession(ctx, exch, symbol, ob, metrics, maxResyncBuffer, &backoff); err != nil {
+			log.Printf("[%s:%s] %v (retrying in %s)", exch.Name(), symbol, err, backoff)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+	}
+}
+
+// runSession fetches a snapshot, subscribes to the depth stream, and
+// applies updates - including buffered gap recovery - until the stream
+// closes or ctx is cancelled. backoff is reset to minBackoff as soon as
+// the stream is established, so a long-lived connection doesn't pay for
+// an earlier failure's backoff on its next reconnect.
+func runSession(ctx context.Context, exch Exchange, symbol string, ob *orderbook.OrderBook, metrics *pairMetrics, maxResyncBuffer int, backoff *time.Duration) error {
+	snap, err := exch.FetchSnapshot(ctx, symbol)
+	if err != nil {
+		return fmt.Errorf("fetch snapshot: %w", err)
+	}
+	if err := ob.ApplySnapshot(snap); err != nil {
+		return fmt.Errorf("apply snapshot: %w", err)
+	}
+	syncID := snap.LastUpdateID
+
+	updates, err := exch.SubscribeDepth(ctx, symbol)
+	if err != nil {
+		return fmt.Errorf("subscribe depth: %w", err)
+	}
+	*backoff = minBackoff
+
+	synced := false
+	var active *resync // non-nil while a gap-recovery snapshot is in flight
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case upd, ok := <-updates:
+			if !ok {
+				return fmt.Errorf("depth stream closed") // runPair backs off, then reconnects
+			}
+
+			if !synced {
+				if upd.FinalID <= syncID {
+					continue // still draining updates older than the snapshot
+				}
+				synced = true
+			}
+
+			if active != nil {
+				active.bufferUpdate(upd, maxResyncBuffer)
+				metrics.bufferedUpdates.Store(int64(len(active.buffer)))
+
+				select {
+				case res := <-active.resultCh:
+					active = finishResync(ob, active, res, metrics)
+				default:
+				}
+				continue
+			}
+
+			if err := ob.ApplyUpdate(upd); err == orderbook.ErrGap {
+				log.Printf("[%s:%s] gap detected, fetching new snapshot in background", exch.Name(), symbol)
+				metrics.gapCount.Add(1)
+				active = startResync(ctx, exch, symbol)
+				active.bufferUpdate(upd, maxResyncBuffer)
+				metrics.bufferedUpdates.Store(int64(len(active.buffer)))
+			}
+		}
+	}
+}
+
+// snapshotResult carries the outcome of a background FetchSnapshot call
+// back to runSession's update loop.
+type snapshotResult struct {
+	snap orderbook.SnapshotMsg
+	err  error
+}
+
+// resync tracks an in-flight gap recovery: updates keep arriving off the
+// depth stream while we wait for a fresh snapshot, and get buffered so
+// they can be replayed once the snapshot lands, instead of being
+// dropped - mirroring the "pending order updates" idea in bbgo's
+// ActiveOrderBook.
+type resync struct {
+	ctx       context.Context
+	exch      Exchange
+	symbol    string
+	resultCh  chan snapshotResult
+	buffer    []orderbook.UpdateMsg
+	startedAt time.Time
+}
+
+func startResync(ctx context.Context, exch Exchange, symbol string) *resync {
+	r := &resync{
+		ctx:       ctx,
+		exch:      exch,
+		symbol:    symbol,
+		resultCh:  make(chan snapshotResult, 1),
+		startedAt: time.Now(),
+	}
+	go func() {
+		snap, err := exch.FetchSnapshot(ctx, symbol)
+		r.resultCh <- snapshotResult{snap: snap, err: err}
+	}()
+	return r
+}
+
+// bufferUpdate appends upd to r's ring buffer, dropping the oldest entry
+// once maxLen is reached so memory stays bounded during a slow resync.
+func (r *resync) bufferUpdate(upd orderbook.UpdateMsg, maxLen int) {
+	if len(r.buffer) >= maxLen {
+		r.buffer = r.buffer[1:]
+	}
+	r.buffer = append(r.buffer, upd)
+}
+
+// finishResync applies a resync snapshot result and, on success, replays
+// every buffered update newer than the snapshot. It returns nil once the
+// resync is complete, or a fresh *resync to keep waiting on if the
+// snapshot fetch itself failed.
+func finishResync(ob *orderbook.OrderBook, r *resync, res snapshotResult, metrics *pairMetrics) *resync {
+	if res.err != nil {
+		log.Printf("[%s:%s] resync snapshot fetch failed, retrying: %v", r.exch.Name(), r.symbol, res.err)
+		retry := startResync(r.ctx, r.exch, r.symbol)
+		retry.buffer = r.buffer
+		metrics.bufferedUpdates.Store(int64(len(retry.buffer)))
+		return retry
+	}
+
+	if err := ob.ApplySnapshot(res.snap); err != nil {
+		log.Printf("[%s:%s] failed to apply resync snapshot: %v", r.exch.Name(), r.symbol, err)
+	}
+
+	replayed := 0
+	for _, buffered := range r.buffer {
+		if buffered.FinalID <= res.snap.LastUpdateID {
+			continue
+		}
+		if err := ob.ApplyUpdate(buffered); err == nil {
+			replayed++
+		}
+	}
+
+	metrics.resyncDurationMs.Store(time.Since(r.startedAt).Milliseconds())
+	metrics.bufferedUpdates.Store(0)
+	log.Printf("[%s:%s] resync complete: replayed %d buffered update(s) in %dms",
+		r.exch.Name(), r.symbol, replayed, metrics.resyncDurationMs.Load())
+	return nil
+}
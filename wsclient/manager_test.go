@@ -0,0 +1,112 @@
+package wsclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"raven-dev-challenge/orderbook"
+)
+
+// fakeExchange is a minimal Exchange whose FetchSnapshot result is driven
+// by the test over snapCh, so a resync fetch can be held "in flight"
+// until the test is ready to let it resolve.
+type fakeExchange struct {
+	snapCh chan snapshotResult
+}
+
+func (f *fakeExchange) Name() string { return "fake" }
+
+func (f *fakeExchange) FetchSnapshot(ctx context.Context, symbol string) (orderbook.SnapshotMsg, error) {
+	select {
+	case r := <-f.snapCh:
+		return r.snap, r.err
+	case <-ctx.Done():
+		return orderbook.SnapshotMsg{}, ctx.Err()
+	}
+}
+
+func (f *fakeExchange) SubscribeDepth(ctx context.Context, symbol string) (<-chan orderbook.UpdateMsg, error) {
+	return nil, nil
+}
+
+func TestPairKey(t *testing.T) {
+	if got, want := pairKey("binance", "btcusdt"), "binance:BTCUSDT"; got != want {
+		t.Fatalf("pairKey() = %q, want %q", got, want)
+	}
+}
+
+func TestResyncBufferUpdateDropsOldest(t *testing.T) {
+	r := &resync{}
+	for i := int64(1); i <= 5; i++ {
+		r.bufferUpdate(orderbook.UpdateMsg{FinalID: i}, 3)
+	}
+
+	if len(r.buffer) != 3 {
+		t.Fatalf("buffer len = %d, want 3", len(r.buffer))
+	}
+	got := []int64{r.buffer[0].FinalID, r.buffer[1].FinalID, r.buffer[2].FinalID}
+	want := []int64{3, 4, 5}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("buffer = %v, want %v", got, want)
+		}
+	}
+}
+
+// TestFinishResyncReplaysOnlyPostSnapshotUpdates drives the gap-recovery
+// path end to end: a snapshot fetch is in flight, updates keep arriving
+// off the depth stream and get buffered, and once the snapshot lands
+// only the updates it doesn't already cover are replayed.
+func TestFinishResyncReplaysOnlyPostSnapshotUpdates(t *testing.T) {
+	ob := orderbook.New()
+	if err := ob.ApplySnapshot(orderbook.SnapshotMsg{LastUpdateID: 10}); err != nil {
+		t.Fatalf("ApplySnapshot: %v", err)
+	}
+
+	fe := &fakeExchange{snapCh: make(chan snapshotResult, 1)}
+	active := startResync(context.Background(), fe, "BTCUSDT")
+
+	// These arrive while the resync snapshot fetch is still in flight.
+	active.bufferUpdate(orderbook.UpdateMsg{FirstID: 11, FinalID: 11}, 10)
+	active.bufferUpdate(orderbook.UpdateMsg{FirstID: 16, FinalID: 20}, 10)
+
+	fe.snapCh <- snapshotResult{snap: orderbook.SnapshotMsg{LastUpdateID: 15}}
+	res := <-active.resultCh
+
+	next := finishResync(ob, active, res, &pairMetrics{})
+	if next != nil {
+		t.Fatalf("expected the resync to complete, got another in-flight resync")
+	}
+	if got := ob.GetLastID(); got != 20 {
+		t.Fatalf("GetLastID() = %d, want 20 (only the post-snapshot update should have replayed)", got)
+	}
+}
+
+// TestFinishResyncRetriesOnFetchFailure covers the other branch of
+// finishResync: a failed resync snapshot fetch starts a fresh fetch and
+// carries the buffered updates over to it rather than dropping them.
+func TestFinishResyncRetriesOnFetchFailure(t *testing.T) {
+	ob := orderbook.New()
+	if err := ob.ApplySnapshot(orderbook.SnapshotMsg{LastUpdateID: 10}); err != nil {
+		t.Fatalf("ApplySnapshot: %v", err)
+	}
+
+	fe := &fakeExchange{snapCh: make(chan snapshotResult, 1)}
+	active := startResync(context.Background(), fe, "BTCUSDT")
+	active.bufferUpdate(orderbook.UpdateMsg{FirstID: 11, FinalID: 11}, 10)
+
+	fe.snapCh <- snapshotResult{err: errors.New("fetch failed")}
+	res := <-active.resultCh
+
+	retry := finishResync(ob, active, res, &pairMetrics{})
+	if retry == nil {
+		t.Fatalf("expected a retry resync after a failed fetch")
+	}
+	if len(retry.buffer) != 1 || retry.buffer[0].FinalID != 11 {
+		t.Fatalf("expected the buffered update to carry over to the retry, got %+v", retry.buffer)
+	}
+	if got := ob.GetLastID(); got != 10 {
+		t.Fatalf("GetLastID() = %d, want unchanged 10 after a failed resync fetch", got)
+	}
+}